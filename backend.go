@@ -0,0 +1,64 @@
+package cache
+
+// Backend is a pluggable persistent store that sits behind a Cache as an L2
+// tier: misses fall through to Load before query is invoked, and values
+// populated via Set or a successful query are written through via Store.
+// See the cache/backend/bolt and cache/backend/redis subpackages for
+// reference implementations.
+type Backend[K comparable, V any] interface {
+	// Load returns the value stored for key, its expiration (UnixNano), and
+	// whether key was found at all.
+	Load(key K) (value V, expiration int64, ok bool)
+	// Store persists value for key with the given expiration (UnixNano).
+	Store(key K, value V, expiration int64) error
+	// Delete removes key from the backend.
+	Delete(key K) error
+	// Range calls fn for every key/value pair in the backend, stopping early
+	// if fn returns false.
+	Range(fn func(key K, value V, expiration int64) bool) error
+}
+
+// WithBackend turns backend into an L2 tier behind the in-memory cache:
+// misses fall through to backend.Load before invoking query, and values
+// populated via Set or a successful query are written through via
+// backend.Store. This turns Cache from a purely process-local cache into a
+// distributed-friendly one.
+func WithBackend[K comparable, V any](backend Backend[K, V]) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.backend = backend
+	}
+}
+
+// loadFromBackend checks the L2 backend for key, populating item and the
+// in-memory eviction/event bookkeeping on a hit. item.mu must be held by the
+// caller.
+func (c *Cache[K, V]) loadFromBackend(key K, item *cacheItem[V], nowTime int64) (V, bool) {
+	if c.backend == nil {
+		var zero V
+		return zero, false
+	}
+
+	val, expiration, ok := c.backend.Load(key)
+	if !ok || expiration <= nowTime {
+		var zero V
+		return zero, false
+	}
+
+	item.val = val
+	item.err = nil
+	item.expiration.Store(expiration)
+	c.track(key, item)
+	c.fire(OnInsertion, key, val, ReasonNone)
+	return val, true
+}
+
+// writeThrough stores value in the L2 backend, if one is configured. Errors
+// are dropped: a write-through failure only means the backend falls behind
+// the in-memory value until it's written again.
+func (c *Cache[K, V]) writeThrough(key K, value V, expiration int64) {
+	if c.backend == nil {
+		return
+	}
+
+	_ = c.backend.Store(key, value, expiration)
+}
@@ -0,0 +1,123 @@
+// Package bolt provides a cache.Backend implementation backed by a single
+// BoltDB bucket, so a Cache can be configured with cache.WithBackend to
+// persist across process restarts.
+package bolt
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	errBucketNotFound = errors.New("bolt: bucket not found")
+	errStopRange      = errors.New("bolt: range stopped")
+)
+
+// record is what's actually stored in the bucket: the gob-encoded value
+// alongside its expiration, so Load doesn't need a second round trip.
+type record[V any] struct {
+	Value      V
+	Expiration int64
+}
+
+// Backend implements cache.Backend[string, V] on top of a single BoltDB
+// bucket, gob-encoding values.
+type Backend[V any] struct {
+	db     *bolt.DB
+	bucket []byte
+}
+
+// New opens (creating if necessary) bucket in db and returns a Backend over it.
+func New[V any](db *bolt.DB, bucket string) (*Backend[V], error) {
+	name := []byte(bucket)
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(name)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &Backend[V]{db: db, bucket: name}, nil
+}
+
+// Load implements cache.Backend.
+func (b *Backend[V]) Load(key string) (value V, expiration int64, ok bool) {
+	_ = b.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(b.bucket)
+		if bucket == nil {
+			return errBucketNotFound
+		}
+
+		data := bucket.Get([]byte(key))
+		if data == nil {
+			return nil
+		}
+
+		var rec record[V]
+		if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&rec); err != nil {
+			return err
+		}
+
+		value, expiration, ok = rec.Value, rec.Expiration, true
+		return nil
+	})
+
+	return value, expiration, ok
+}
+
+// Store implements cache.Backend.
+func (b *Backend[V]) Store(key string, value V, expiration int64) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(record[V]{Value: value, Expiration: expiration}); err != nil {
+		return err
+	}
+
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(b.bucket)
+		if bucket == nil {
+			return errBucketNotFound
+		}
+		return bucket.Put([]byte(key), buf.Bytes())
+	})
+}
+
+// Delete implements cache.Backend.
+func (b *Backend[V]) Delete(key string) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(b.bucket)
+		if bucket == nil {
+			return errBucketNotFound
+		}
+		return bucket.Delete([]byte(key))
+	})
+}
+
+// Range implements cache.Backend.
+func (b *Backend[V]) Range(fn func(key string, value V, expiration int64) bool) error {
+	err := b.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(b.bucket)
+		if bucket == nil {
+			return errBucketNotFound
+		}
+
+		return bucket.ForEach(func(k, data []byte) error {
+			var rec record[V]
+			if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&rec); err != nil {
+				return err
+			}
+
+			if !fn(string(k), rec.Value, rec.Expiration) {
+				return errStopRange
+			}
+			return nil
+		})
+	})
+
+	if errors.Is(err, errStopRange) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,116 @@
+package bolt
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	boltdb "go.etcd.io/bbolt"
+)
+
+func openTestBackend(t *testing.T) *Backend[int] {
+	t.Helper()
+
+	db, err := boltdb.Open(filepath.Join(t.TempDir(), "cache.db"), 0600, nil)
+	if err != nil {
+		t.Fatalf("open bolt db: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	backend, err := New[int](db, "cache")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return backend
+}
+
+func TestBackendStoreLoad(t *testing.T) {
+	backend := openTestBackend(t)
+
+	exp := time.Now().Add(time.Minute).UnixNano()
+	if err := backend.Store("test", 42, exp); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	value, expiration, ok := backend.Load("test")
+	if !ok {
+		t.Fatalf("expected key to be found")
+	}
+	if value != 42 {
+		t.Fatalf("expected value 42, got %d", value)
+	}
+	if expiration != exp {
+		t.Fatalf("expected expiration %d, got %d", exp, expiration)
+	}
+}
+
+func TestBackendLoadMissing(t *testing.T) {
+	backend := openTestBackend(t)
+
+	if _, _, ok := backend.Load("missing"); ok {
+		t.Fatalf("expected missing key to not be found")
+	}
+}
+
+func TestBackendDelete(t *testing.T) {
+	backend := openTestBackend(t)
+
+	if err := backend.Store("test", 1, time.Now().Add(time.Minute).UnixNano()); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	if err := backend.Delete("test"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if _, _, ok := backend.Load("test"); ok {
+		t.Fatalf("expected key to be gone after Delete")
+	}
+}
+
+func TestBackendRange(t *testing.T) {
+	backend := openTestBackend(t)
+
+	now := time.Now().UnixNano()
+	if err := backend.Store("a", 1, now+int64(time.Minute)); err != nil {
+		t.Fatalf("Store a: %v", err)
+	}
+	if err := backend.Store("b", 2, now-int64(time.Minute)); err != nil {
+		t.Fatalf("Store b: %v", err)
+	}
+
+	seen := map[string]int{}
+	if err := backend.Range(func(key string, value int, expiration int64) bool {
+		seen[key] = value
+		return true
+	}); err != nil {
+		t.Fatalf("Range: %v", err)
+	}
+
+	if len(seen) != 2 || seen["a"] != 1 || seen["b"] != 2 {
+		t.Fatalf("expected both entries from Range, got %v", seen)
+	}
+}
+
+func TestBackendRangeStopsEarly(t *testing.T) {
+	backend := openTestBackend(t)
+
+	now := time.Now().UnixNano()
+	for _, key := range []string{"a", "b", "c"} {
+		if err := backend.Store(key, 1, now+int64(time.Minute)); err != nil {
+			t.Fatalf("Store %s: %v", key, err)
+		}
+	}
+
+	count := 0
+	if err := backend.Range(func(key string, value int, expiration int64) bool {
+		count++
+		return false
+	}); err != nil {
+		t.Fatalf("Range: %v", err)
+	}
+
+	if count != 1 {
+		t.Fatalf("expected Range to stop after 1 entry, got %d", count)
+	}
+}
@@ -0,0 +1,95 @@
+// Package redis provides a cache.Backend implementation backed by Redis, so a
+// Cache can be configured with cache.WithBackend to share an L2 tier across
+// process instances. Values are JSON-encoded; expiration is enforced
+// server-side via EXPIREAT.
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Backend implements cache.Backend[string, V] on top of a Redis client.
+type Backend[V any] struct {
+	client *redis.Client
+	prefix string
+}
+
+// New returns a Backend that stores keys under prefix+key in client.
+func New[V any](client *redis.Client, prefix string) *Backend[V] {
+	return &Backend[V]{client: client, prefix: prefix}
+}
+
+func (b *Backend[V]) key(key string) string {
+	return b.prefix + key
+}
+
+// Load implements cache.Backend.
+func (b *Backend[V]) Load(key string) (value V, expiration int64, ok bool) {
+	ctx := context.Background()
+
+	data, err := b.client.Get(ctx, b.key(key)).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			return value, 0, false
+		}
+		return value, 0, false
+	}
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		return value, 0, false
+	}
+
+	ttl, err := b.client.TTL(ctx, b.key(key)).Result()
+	if err != nil || ttl < 0 {
+		return value, 0, false
+	}
+
+	return value, time.Now().Add(ttl).UnixNano(), true
+}
+
+// Store implements cache.Backend. expiration is enforced server-side via
+// EXPIREAT so Redis reaps the key on its own.
+func (b *Backend[V]) Store(key string, value V, expiration int64) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if err := b.client.Set(ctx, b.key(key), data, 0).Err(); err != nil {
+		return err
+	}
+
+	return b.client.ExpireAt(ctx, b.key(key), time.Unix(0, expiration)).Err()
+}
+
+// Delete implements cache.Backend.
+func (b *Backend[V]) Delete(key string) error {
+	return b.client.Del(context.Background(), b.key(key)).Err()
+}
+
+// Range implements cache.Backend by scanning all keys under prefix.
+func (b *Backend[V]) Range(fn func(key string, value V, expiration int64) bool) error {
+	ctx := context.Background()
+
+	iter := b.client.Scan(ctx, 0, b.prefix+"*", 0).Iterator()
+	for iter.Next(ctx) {
+		shortKey := iter.Val()[len(b.prefix):]
+
+		value, expiration, ok := b.Load(shortKey)
+		if !ok {
+			continue
+		}
+
+		if !fn(shortKey, value, expiration) {
+			break
+		}
+	}
+
+	return iter.Err()
+}
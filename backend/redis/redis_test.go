@@ -0,0 +1,105 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// newTestBackend returns a Backend against a local Redis instance, skipping
+// the test if one isn't reachable. These are integration tests: unlike the
+// bolt backend, Redis isn't embeddable, so there's no in-process substitute.
+func newTestBackend(t *testing.T) *Backend[int] {
+	t.Helper()
+
+	client := goredis.NewClient(&goredis.Options{Addr: "127.0.0.1:6379"})
+	t.Cleanup(func() { _ = client.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skipf("no redis available at 127.0.0.1:6379: %v", err)
+	}
+
+	prefix := "cache-test:"
+	t.Cleanup(func() {
+		keys, _ := client.Keys(context.Background(), prefix+"*").Result()
+		if len(keys) > 0 {
+			_ = client.Del(context.Background(), keys...).Err()
+		}
+	})
+
+	return New[int](client, prefix)
+}
+
+func TestBackendStoreLoad(t *testing.T) {
+	backend := newTestBackend(t)
+
+	exp := time.Now().Add(time.Minute).UnixNano()
+	if err := backend.Store("test", 42, exp); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	value, expiration, ok := backend.Load("test")
+	if !ok {
+		t.Fatalf("expected key to be found")
+	}
+	if value != 42 {
+		t.Fatalf("expected value 42, got %d", value)
+	}
+	// Redis enforces expiration server-side via TTL, so the returned
+	// expiration is reconstructed from TTL and only approximate.
+	if expiration < time.Now().UnixNano() {
+		t.Fatalf("expected expiration in the future, got %d", expiration)
+	}
+}
+
+func TestBackendLoadMissing(t *testing.T) {
+	backend := newTestBackend(t)
+
+	if _, _, ok := backend.Load("missing"); ok {
+		t.Fatalf("expected missing key to not be found")
+	}
+}
+
+func TestBackendDelete(t *testing.T) {
+	backend := newTestBackend(t)
+
+	if err := backend.Store("test", 1, time.Now().Add(time.Minute).UnixNano()); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	if err := backend.Delete("test"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if _, _, ok := backend.Load("test"); ok {
+		t.Fatalf("expected key to be gone after Delete")
+	}
+}
+
+func TestBackendRange(t *testing.T) {
+	backend := newTestBackend(t)
+
+	exp := time.Now().Add(time.Minute).UnixNano()
+	if err := backend.Store("a", 1, exp); err != nil {
+		t.Fatalf("Store a: %v", err)
+	}
+	if err := backend.Store("b", 2, exp); err != nil {
+		t.Fatalf("Store b: %v", err)
+	}
+
+	seen := map[string]int{}
+	if err := backend.Range(func(key string, value int, expiration int64) bool {
+		seen[key] = value
+		return true
+	}); err != nil {
+		t.Fatalf("Range: %v", err)
+	}
+
+	if len(seen) != 2 || seen["a"] != 1 || seen["b"] != 2 {
+		t.Fatalf("expected both entries from Range, got %v", seen)
+	}
+}
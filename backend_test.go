@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// memBackend is a minimal in-memory Backend[string, V] used to exercise
+// WithBackend without pulling in the bolt/redis subpackages.
+type memBackend[V any] struct {
+	mu    sync.Mutex
+	items map[string]record[V]
+}
+
+type record[V any] struct {
+	value      V
+	expiration int64
+}
+
+func newMemBackend[V any]() *memBackend[V] {
+	return &memBackend[V]{items: make(map[string]record[V])}
+}
+
+func (b *memBackend[V]) Load(key string) (value V, expiration int64, ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	rec, ok := b.items[key]
+	return rec.value, rec.expiration, ok
+}
+
+func (b *memBackend[V]) Store(key string, value V, expiration int64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.items[key] = record[V]{value: value, expiration: expiration}
+	return nil
+}
+
+func (b *memBackend[V]) Delete(key string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.items, key)
+	return nil
+}
+
+func (b *memBackend[V]) Range(fn func(key string, value V, expiration int64) bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for key, rec := range b.items {
+		if !fn(key, rec.value, rec.expiration) {
+			break
+		}
+	}
+	return nil
+}
+
+func TestCacheWithBackendFallthrough(t *testing.T) {
+	backend := newMemBackend[int]()
+	backend.Store("test", 99, now()+int64(time.Minute))
+
+	count := 0
+	cache := New(time.Minute, func(key string) (int, error) {
+		count++
+		return len(key), nil
+	}, WithBackend[string, int](backend))
+
+	result, err := cache.Get("test")
+	requireNoError(t, err)
+	requireEqual(t, 99, result)
+	requireEqual(t, 0, count)
+}
+
+func TestCacheWithBackendWriteThrough(t *testing.T) {
+	backend := newMemBackend[int]()
+	cache := New(time.Minute, func(key string) (int, error) {
+		return len(key), nil
+	}, WithBackend[string, int](backend))
+
+	cache.Set("test", 7)
+
+	value, _, ok := backend.Load("test")
+	if !ok {
+		t.Fatalf("expected backend to have been written through")
+	}
+	requireEqual(t, 7, value)
+}
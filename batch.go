@@ -0,0 +1,161 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+const _defaultGetMultiConcurrency = 16
+
+// WithGetMultiConcurrency bounds how many query calls GetMulti issues at
+// once for keys it misses on. If not supplied, GetMulti uses 16.
+func WithGetMultiConcurrency[K comparable, V any](n int) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.getMultiConcurrency = n
+	}
+}
+
+// Delete removes key from the cache and reports whether it was present.
+func (c *Cache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	item, ok := c.items[key]
+	if ok {
+		delete(c.items, key)
+		c.untrackLocked(key, item)
+		c.size.Store(int64(len(c.items)))
+	}
+	c.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	c.fire(OnEviction, key, item.val, ReasonManualDelete)
+	if c.backend != nil {
+		_ = c.backend.Delete(key)
+	}
+
+	return true
+}
+
+// Keys returns a snapshot of every key currently in the cache, including
+// expired ones not yet purged by cleanup.
+func (c *Cache[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]K, 0, len(c.items))
+	for key := range c.items {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Len returns the number of keys currently in the cache, including expired
+// ones not yet purged by cleanup.
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// Purge removes every entry from the cache.
+func (c *Cache[K, V]) Purge() {
+	c.mu.Lock()
+	items := c.items
+	c.items = make(map[K]*cacheItem[V])
+	if c.maxEntries > 0 {
+		if c.policy == LFU {
+			c.freqBuckets = make(map[int]*list.List)
+		} else {
+			c.order = list.New()
+		}
+		c.minFreq = 0
+	}
+	c.size.Store(0)
+	c.mu.Unlock()
+
+	for key, item := range items {
+		c.fire(OnEviction, key, item.val, ReasonManualDelete)
+		if c.backend != nil {
+			_ = c.backend.Delete(key)
+		}
+	}
+}
+
+// Snapshot returns a copy of every non-expired key/value pair in the cache.
+func (c *Cache[K, V]) Snapshot() map[K]V {
+	keys := c.Keys()
+	snapshot := make(map[K]V, len(keys))
+
+	nowTime := now()
+	for _, key := range keys {
+		c.mu.Lock()
+		item, ok := c.items[key]
+		c.mu.Unlock()
+
+		if ok && item.expiration.Load() >= nowTime {
+			snapshot[key] = item.val
+		}
+	}
+
+	return snapshot
+}
+
+// Range calls fn for every non-expired key/value pair in the cache, stopping
+// early if fn returns false. It copies the key set up front and only holds
+// c.mu for the short lookup of each entry, so it never blocks Get or Set for
+// the whole walk.
+func (c *Cache[K, V]) Range(fn func(key K, value V) bool) {
+	nowTime := now()
+	for _, key := range c.Keys() {
+		c.mu.Lock()
+		item, ok := c.items[key]
+		c.mu.Unlock()
+
+		if !ok || item.expiration.Load() < nowTime {
+			continue
+		}
+
+		if !fn(key, item.val) {
+			return
+		}
+	}
+}
+
+// GetMulti fans Get out across keys concurrently, bounded by
+// WithGetMultiConcurrency (16 by default). It returns partial results: values
+// holds every key that resolved successfully, and errs holds every key whose
+// query returned an error.
+func (c *Cache[K, V]) GetMulti(keys []K, ttl ...time.Duration) (values map[K]V, errs map[K]error) {
+	values = make(map[K]V, len(keys))
+	errs = make(map[K]error)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, c.getMultiConcurrency)
+
+	for _, key := range keys {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(key K) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			val, err := c.Get(key, ttl...)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[key] = err
+			} else {
+				values[key] = val
+			}
+		}(key)
+	}
+
+	wg.Wait()
+	return values, errs
+}
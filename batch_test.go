@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCacheDelete(t *testing.T) {
+	cache := New(time.Minute, func(key string) (int, error) {
+		return len(key), nil
+	})
+
+	cache.Set("test", 10)
+	requireEqual(t, true, cache.Delete("test"))
+	requireEqual(t, false, cache.Delete("test"))
+	requireEqual(t, 0, cache.Len())
+}
+
+func TestCacheKeysLenPurge(t *testing.T) {
+	cache := New(time.Minute, func(key string) (int, error) {
+		return len(key), nil
+	})
+
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+
+	requireEqual(t, 2, cache.Len())
+	requireEqual(t, 2, len(cache.Keys()))
+
+	cache.Purge()
+
+	requireEqual(t, 0, cache.Len())
+}
+
+func TestCacheSnapshotAndRange(t *testing.T) {
+	cache := New(time.Minute, func(key string) (int, error) {
+		return len(key), nil
+	})
+
+	cache.Set("a", 1)
+	cache.Set("bb", 2)
+
+	snapshot := cache.Snapshot()
+	requireEqual(t, 2, len(snapshot))
+	requireEqual(t, 1, snapshot["a"])
+	requireEqual(t, 2, snapshot["bb"])
+
+	visited := map[string]int{}
+	cache.Range(func(key string, value int) bool {
+		visited[key] = value
+		return true
+	})
+	requireEqual(t, 2, len(visited))
+}
+
+func TestCacheGetMulti(t *testing.T) {
+	cache := New(time.Minute, func(key string) (int, error) {
+		if key == "bad" {
+			return 0, errors.New("boom")
+		}
+		return len(key), nil
+	})
+
+	values, errs := cache.GetMulti([]string{"a", "bb", "bad"})
+
+	requireEqual(t, 1, values["a"])
+	requireEqual(t, 2, values["bb"])
+	requireEqual(t, 2, len(values))
+	requireError(t, errs["bad"])
+	requireEqual(t, 1, len(errs))
+}
@@ -22,9 +22,23 @@
 //
 //	// Set a value with custom TTL
 //	c.Set("shortlived", 100, 10*time.Second)
+//
+// New also accepts Options to opt into negative caching, serve-stale-while-revalidate,
+// and single-flight coordination of concurrent cache misses:
+//
+//	c := cache.New(5*time.Minute, query,
+//		cache.WithNegativeTTL[string, int](10*time.Second),
+//		cache.WithServeExpired[string, int](),
+//		cache.WithSingleFlight[string, int](),
+//	)
+//
+// GetContext honors cancellation while waiting on a cache miss, and Close
+// stops the background cleanup goroutine once a Cache is no longer needed.
 package cache
 
 import (
+	"container/list"
+	"context"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -41,43 +55,167 @@ type Cache[K comparable, V any] struct {
 	mu         sync.Mutex
 	items      map[K]*cacheItem[V]
 	defaultTTL time.Duration
-	query      func(key K) (V, error)
+	query      func(ctx context.Context, key K) (V, error)
+
+	done      chan struct{}
+	closeOnce sync.Once
+
+	cleanupInterval time.Duration
+	negativeTTL     time.Duration
+	serveExpired    bool
+	singleFlight    bool
+
+	sfMu     sync.Mutex
+	inflight map[K]*inflightCall[V]
+
+	maxEntries  int
+	policy      EvictionPolicy
+	order       *list.List         // LRU/FIFO: list.Element.Value is K, front = most recent/newest
+	freqBuckets map[int]*list.List // LFU: frequency -> list of K, à la O(1) LFU
+	minFreq     int
+
+	hits      atomic.Int64
+	misses    atomic.Int64
+	evictions atomic.Int64
+	size      atomic.Int64
+
+	subsMu sync.Mutex
+	subs   map[EventKind][]*subscriber[K, V]
+
+	backend Backend[K, V]
+
+	getMultiConcurrency int
 }
 
 type cacheItem[V any] struct {
 	expiration atomic.Int64 /* nanoseconds */
 	mu         sync.RWMutex
 	val        V
+	err        error
+
+	node     *list.Element // LRU/FIFO position within Cache.order
+	freq     int           // LFU: current frequency bucket
+	freqNode *list.Element // LFU: position within Cache.freqBuckets[freq]
+}
+
+// inflightCall tracks a query in progress for a single key so that concurrent
+// callers waiting on the same key can share its result instead of each
+// triggering their own call to query.
+type inflightCall[V any] struct {
+	done chan struct{}
+	val  V
+	err  error
+}
+
+// Option configures optional behavior of a Cache created by New.
+type Option[K comparable, V any] func(*Cache[K, V])
+
+// WithCleanupInterval overrides the interval at which expired items are purged
+// in the background. If not supplied, New uses a 15-minute interval.
+func WithCleanupInterval[K comparable, V any](interval time.Duration) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.cleanupInterval = interval
+	}
+}
+
+// WithNegativeTTL enables negative caching: when query returns an error, the
+// error is cached for the given TTL so that repeated calls for the same key
+// return the cached error immediately instead of hammering the upstream.
+func WithNegativeTTL[K comparable, V any](ttl time.Duration) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.negativeTTL = ttl
+	}
+}
+
+// WithServeExpired enables stale-while-revalidate behavior. When an item has
+// expired but a previous value is still present, Get returns that stale value
+// immediately and refreshes it by calling query in a background goroutine, so
+// latency-sensitive callers never block on query.
+func WithServeExpired[K comparable, V any]() Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.serveExpired = true
+	}
+}
+
+// WithSingleFlight coordinates concurrent cache misses on the same key so that
+// only one call to query is in flight at a time; every other caller waiting
+// on that key receives the same result instead of issuing its own query.
+func WithSingleFlight[K comparable, V any]() Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.singleFlight = true
+	}
 }
 
 // New creates a new Cache instance with the given default TTL and query function.
-// The cache will automatically clean up expired items in the background.
+// The cache will automatically clean up expired items in the background until
+// Close is called.
 //
 // Parameters:
 //   - defaultExpiration: The default TTL for items in the cache.
 //   - query: A function that takes a key and returns a value and an error.
-//   - cleanupInterval: The interval at which the cache should clean up expired items.
-//     If not provided, the default interval of 15 minutes will be used.
-func New[K comparable, V any](defaultExpiration time.Duration, query func(key K) (V, error), cleanupInterval ...time.Duration) *Cache[K, V] {
+//   - opts: Options to customize cache behavior, such as WithCleanupInterval,
+//     WithNegativeTTL, WithServeExpired, and WithSingleFlight. With no options,
+//     New behaves exactly as before.
+func New[K comparable, V any](defaultExpiration time.Duration, query func(key K) (V, error), opts ...Option[K, V]) *Cache[K, V] {
+	return newCache(defaultExpiration, func(_ context.Context, key K) (V, error) {
+		return query(key)
+	}, opts...)
+}
+
+// NewWithContext is like New, but query additionally receives the context
+// passed to GetContext (or context.Background() for plain Get calls), so a
+// query that talks to an upstream can observe cancellation.
+func NewWithContext[K comparable, V any](defaultExpiration time.Duration, query func(ctx context.Context, key K) (V, error), opts ...Option[K, V]) *Cache[K, V] {
+	return newCache(defaultExpiration, query, opts...)
+}
+
+func newCache[K comparable, V any](defaultExpiration time.Duration, query func(ctx context.Context, key K) (V, error), opts ...Option[K, V]) *Cache[K, V] {
 	c := &Cache[K, V]{
-		items:      make(map[K]*cacheItem[V]),
-		defaultTTL: defaultExpiration,
-		query:      query,
+		items:               make(map[K]*cacheItem[V]),
+		defaultTTL:          defaultExpiration,
+		query:               query,
+		cleanupInterval:     _defaultCleanupInterval,
+		inflight:            make(map[K]*inflightCall[V]),
+		done:                make(chan struct{}),
+		getMultiConcurrency: _defaultGetMultiConcurrency,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.maxEntries > 0 && c.policy == LFU {
+		c.freqBuckets = make(map[int]*list.List)
+	} else if c.maxEntries > 0 {
+		c.order = list.New()
 	}
 
 	go func() {
-		ticker := time.NewTicker(firstOrDefault(_defaultCleanupInterval, cleanupInterval...))
+		ticker := time.NewTicker(c.cleanupInterval)
 		defer ticker.Stop()
 
-		for range ticker.C {
-			c.cleanup()
-
+		for {
+			select {
+			case <-ticker.C:
+				c.cleanup()
+			case <-c.done:
+				return
+			}
 		}
 	}()
 
 	return c
 }
 
+// Close stops the background cleanup goroutine. The cache remains usable
+// afterward, but expired items are no longer purged automatically. Close is
+// safe to call more than once.
+func (c *Cache[K, V]) Close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+	})
+}
+
 func (c *Cache[K, V]) cleanup() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -85,8 +223,12 @@ func (c *Cache[K, V]) cleanup() {
 	for key, item := range c.items {
 		if item.expiration.Load() < now() {
 			delete(c.items, key)
+			c.untrackLocked(key, item)
+			c.fire(OnEviction, key, item.val, ReasonExpired)
 		}
 	}
+
+	c.size.Store(int64(len(c.items)))
 }
 func (c *Cache[K, V]) getItem(key K) *cacheItem[V] {
 	c.mu.Lock()
@@ -98,6 +240,7 @@ func (c *Cache[K, V]) getItem(key K) *cacheItem[V] {
 
 	item = &cacheItem[V]{}
 	c.items[key] = item
+	c.size.Store(int64(len(c.items)))
 	return item
 }
 
@@ -109,7 +252,39 @@ func (c *Cache[K, V]) getItem(key K) *cacheItem[V] {
 //   - key: The key of the value to retrieve.
 //   - ttl: The TTL for the value. If not provided, the default TTL will be used.
 func (c *Cache[K, V]) Get(key K, ttl ...time.Duration) (V, error) {
-	return getAndUpdateItemFromQuery(key, c.getItem(key), c.query, firstOrDefault(c.defaultTTL, ttl...).Nanoseconds())
+	return c.getAndUpdateItemFromQuery(context.Background(), key, c.getItem(key), firstOrDefault(c.defaultTTL, ttl...).Nanoseconds())
+}
+
+// GetContext is like Get but honors ctx: if ctx is canceled while waiting on
+// the per-item lock or on an in-flight query, it returns ctx.Err() without
+// corrupting the item, and other callers waiting on the same key are
+// unaffected and continue to completion.
+func (c *Cache[K, V]) GetContext(ctx context.Context, key K, ttl ...time.Duration) (V, error) {
+	item := c.getItem(key)
+	ttlNanos := firstOrDefault(c.defaultTTL, ttl...).Nanoseconds()
+
+	if item.expiration.Load() > now() {
+		return c.getAndUpdateItemFromQuery(ctx, key, item, ttlNanos)
+	}
+
+	type result struct {
+		val V
+		err error
+	}
+
+	resCh := make(chan result, 1)
+	go func() {
+		val, err := c.getAndUpdateItemFromQuery(ctx, key, item, ttlNanos)
+		resCh <- result{val, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	case r := <-resCh:
+		return r.val, r.err
+	}
 }
 
 // Set adds a value to the cache.
@@ -122,7 +297,11 @@ func (c *Cache[K, V]) Get(key K, ttl ...time.Duration) (V, error) {
 //   - ttl: The TTL for the value. If not provided, the default TTL will be used.
 func (c *Cache[K, V]) Set(key K, value V, ttl ...time.Duration) {
 	if !isZeroTTL(ttl...) {
-		updateItem(key, value, c.getItem(key), firstOrDefault(c.defaultTTL, ttl...).Nanoseconds())
+		item := c.getItem(key)
+		updateItem(key, value, item, firstOrDefault(c.defaultTTL, ttl...).Nanoseconds())
+		c.track(key, item)
+		c.fire(OnInsertion, key, value, ReasonNone)
+		c.writeThrough(key, value, item.expiration.Load())
 	}
 }
 
@@ -147,28 +326,115 @@ func updateItem[K comparable, V any](key K, val V, item *cacheItem[V], ttl int64
 	defer item.mu.Unlock()
 
 	item.val = val
+	item.err = nil
 	item.expiration.Store(now() + ttl)
 }
 
-func getAndUpdateItemFromQuery[K comparable, V any](key K, item *cacheItem[V], query func(key K) (V, error), ttl int64) (V, error) {
+func (c *Cache[K, V]) getAndUpdateItemFromQuery(ctx context.Context, key K, item *cacheItem[V], ttl int64) (V, error) {
 	nowTime := now()
 	if item.expiration.Load() > nowTime {
-		return item.val, nil
+		c.hits.Add(1)
+		c.touch(key, item)
+		c.fire(OnHit, key, item.val, ReasonNone)
+		return item.val, item.err
+	}
+
+	c.misses.Add(1)
+
+	if c.serveExpired && item.expiration.Load() != 0 {
+		val, err := item.val, item.err
+		go c.doQuery(context.Background(), key, item, nowTime, ttl)
+		return val, err
+	}
+
+	return c.doQuery(ctx, key, item, nowTime, ttl)
+}
+
+// doQuery calls query for key and stores the result on item, coordinating
+// concurrent callers for the same key via single-flight when enabled.
+func (c *Cache[K, V]) doQuery(ctx context.Context, key K, item *cacheItem[V], nowTime, ttl int64) (V, error) {
+	if !c.singleFlight {
+		item.mu.Lock()
+		defer item.mu.Unlock()
+
+		if item.expiration.Load() > nowTime {
+			return item.val, item.err
+		}
+
+		if val, ok := c.loadFromBackend(key, item, nowTime); ok {
+			return val, nil
+		}
+
+		val, err := c.query(ctx, key)
+		if err != nil && ctx.Err() != nil {
+			// the caller gave up; its cancellation isn't recorded on the item,
+			// so the next caller on key runs query itself.
+			return item.val, err
+		}
+		return c.storeQueryResult(key, item, val, err, ttl)
+	}
+
+	c.sfMu.Lock()
+	if call, ok := c.inflight[key]; ok {
+		c.sfMu.Unlock()
+		<-call.done
+		return call.val, call.err
 	}
 
+	call := &inflightCall[V]{done: make(chan struct{})}
+	c.inflight[key] = call
+	c.sfMu.Unlock()
+
 	item.mu.Lock()
-	defer item.mu.Unlock()
+	val, fromBackend := c.loadFromBackend(key, item, nowTime)
+	item.mu.Unlock()
 
-	if item.expiration.Load() > nowTime {
-		return item.val, nil
+	if fromBackend {
+		call.val, call.err = val, nil
+	} else {
+		// query runs without holding item.mu, so a concurrent Set on this key
+		// isn't blocked for the duration of the call; only the final store
+		// below needs the lock. It also deliberately doesn't use ctx: this
+		// result is shared with every other waiter on key, and ctx belongs to
+		// whichever caller happened to become the leader, so canceling it
+		// must not fail or negative-cache the query for callers who never
+		// canceled anything. A waiter's own cancellation is still honored by
+		// GetContext racing ctx.Done() against this call independently.
+		val, err := c.query(context.Background(), key)
+
+		item.mu.Lock()
+		call.val, call.err = c.storeQueryResult(key, item, val, err, ttl)
+		item.mu.Unlock()
 	}
 
-	val, err := query(key)
+	c.sfMu.Lock()
+	delete(c.inflight, key)
+	c.sfMu.Unlock()
+	close(call.done)
+
+	return call.val, call.err
+}
+
+// storeQueryResult records the outcome of a query call on item. item.mu must
+// be held by the caller. On error, the value is only cached (as a negative
+// entry) if WithNegativeTTL was configured; otherwise the item is left as-is
+// so the next Get retries query.
+func (c *Cache[K, V]) storeQueryResult(key K, item *cacheItem[V], val V, err error, ttl int64) (V, error) {
 	if err != nil {
+		c.fire(OnMiss, key, val, ReasonQueryError)
+		if c.negativeTTL > 0 {
+			item.err = err
+			item.expiration.Store(now() + c.negativeTTL.Nanoseconds())
+			c.track(key, item)
+		}
 		return item.val, err
 	}
 
 	item.val = val
+	item.err = nil
 	item.expiration.Store(now() + ttl)
+	c.track(key, item)
+	c.fire(OnInsertion, key, val, ReasonNone)
+	c.writeThrough(key, val, item.expiration.Load())
 	return val, nil
 }
@@ -118,7 +118,7 @@ func TestCacheGetGood(t *testing.T) {
 func TestCacheCleanup(t *testing.T) {
 	cache := New(time.Second, func(key string) (int, error) {
 		return len(key), nil
-	}, 3*time.Second)
+	}, WithCleanupInterval[string, int](3*time.Second))
 
 	cache.Set("test", 10, time.Second)
 	cache.Set("test2", 12, time.Second)
@@ -175,6 +175,76 @@ func TestCacheSet(t *testing.T) {
 	requireEqual(t, 4, result)
 }
 
+func TestCacheNegativeTTL(t *testing.T) {
+	count := atomic.Int64{}
+	cache := New(time.Hour, func(key string) (int, error) {
+		count.Add(1)
+		return 0, errors.New("upstream error")
+	}, WithNegativeTTL[string, int](3*time.Second))
+
+	_, err := cache.Get("test")
+	requireError(t, err)
+
+	_, err = cache.Get("test")
+	requireError(t, err)
+
+	requireEqual(t, int64(1), count.Load())
+
+	<-time.After(3 * time.Second)
+
+	_, err = cache.Get("test")
+	requireError(t, err)
+	requireEqual(t, int64(2), count.Load())
+}
+
+func TestCacheServeExpired(t *testing.T) {
+	count := atomic.Int64{}
+	cache := New(time.Second, func(key string) (int, error) {
+		n := count.Add(1)
+		<-time.After(500 * time.Millisecond)
+		return int(n), nil
+	}, WithServeExpired[string, int]())
+
+	result, err := cache.Get("test")
+	requireNoError(t, err)
+	requireEqual(t, 1, result)
+
+	<-time.After(2 * time.Second)
+
+	result, err = cache.Get("test")
+	requireNoError(t, err)
+	requireEqual(t, 1, result)
+
+	<-time.After(time.Second)
+
+	result, err = cache.Get("test")
+	requireNoError(t, err)
+	requireEqual(t, 2, result)
+}
+
+func TestCacheSingleFlight(t *testing.T) {
+	count := atomic.Int64{}
+	cache := New(time.Second, func(key string) (int, error) {
+		<-time.After(time.Second)
+		count.Add(1)
+		return len(key), nil
+	}, WithSingleFlight[string, int]())
+
+	wg := sync.WaitGroup{}
+	wg.Add(10)
+	for i := 0; i < 10; i++ {
+		go func() {
+			defer wg.Done()
+			result, err := cache.Get("test")
+			requireNoError(t, err)
+			requireEqual(t, 4, result)
+		}()
+	}
+
+	wg.Wait()
+	requireEqual(t, int64(1), count.Load())
+}
+
 func requireError(t *testing.T, err error) {
 	t.Helper()
 	if err == nil {
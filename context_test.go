@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCacheGetContextCancel(t *testing.T) {
+	cache := New(time.Minute, func(key string) (int, error) {
+		<-time.After(time.Second)
+		return len(key), nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := cache.GetContext(ctx, "test")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	// the query started by the canceled call should still complete and
+	// populate the item for later callers.
+	<-time.After(2 * time.Second)
+
+	result, err := cache.Get("test")
+	requireNoError(t, err)
+	requireEqual(t, 4, result)
+}
+
+func TestCacheSingleFlightCancelDoesNotAffectOthers(t *testing.T) {
+	cache := New(time.Minute, func(key string) (int, error) {
+		<-time.After(200 * time.Millisecond)
+		return len(key), nil
+	}, WithSingleFlight[string, int](), WithNegativeTTL[string, int](time.Minute))
+
+	shortCtx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, err := cache.GetContext(shortCtx, "test")
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected context.DeadlineExceeded for the canceled caller, got %v", err)
+		}
+	}()
+
+	// give the canceled caller a head start so it's likely to become the
+	// single-flight leader before its own deadline fires.
+	<-time.After(10 * time.Millisecond)
+
+	result, err := cache.GetContext(context.Background(), "test")
+	wg.Wait()
+
+	requireNoError(t, err)
+	requireEqual(t, 4, result)
+
+	// the canceled leader's ctx.Err() must not have been written into the
+	// item as a negative-cached result; a plain Get must see the real value.
+	result, err = cache.Get("test")
+	requireNoError(t, err)
+	requireEqual(t, 4, result)
+}
+
+func TestCacheNewWithContext(t *testing.T) {
+	cache := NewWithContext(time.Minute, func(ctx context.Context, key string) (int, error) {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		return len(key), nil
+	})
+
+	result, err := cache.GetContext(context.Background(), "test")
+	requireNoError(t, err)
+	requireEqual(t, 4, result)
+}
+
+func TestCacheClose(t *testing.T) {
+	cache := New(time.Second, func(key string) (int, error) {
+		return len(key), nil
+	}, WithCleanupInterval[string, int](time.Second))
+
+	cache.Set("test", 10, time.Second)
+	requireEqual(t, 1, len(cache.items))
+
+	cache.Close()
+	cache.Close() // must be safe to call twice
+
+	<-time.After(3 * time.Second)
+
+	// cleanup goroutine stopped, so the expired item is still present
+	requireEqual(t, 1, len(cache.items))
+}
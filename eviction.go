@@ -0,0 +1,192 @@
+package cache
+
+import "container/list"
+
+// EvictionPolicy selects how entries are chosen for removal once a cache
+// configured with WithMaxEntries is full.
+type EvictionPolicy int
+
+const (
+	// LRU evicts the least recently used entry.
+	LRU EvictionPolicy = iota
+	// LFU evicts the least frequently used entry, using an O(1) frequency-bucket
+	// implementation: a map of frequency to a doubly-linked list of keys, plus a
+	// per-item pointer into its current bucket.
+	LFU
+	// FIFO evicts the oldest inserted entry, regardless of access pattern.
+	FIFO
+)
+
+// WithMaxEntries bounds the cache to at most n entries, evicting according to
+// policy once that bound would be exceeded. Without this option the cache
+// grows unboundedly between cleanup ticks.
+func WithMaxEntries[K comparable, V any](n int, policy EvictionPolicy) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.maxEntries = n
+		c.policy = policy
+	}
+}
+
+// Metrics reports point-in-time cache statistics, useful for wiring into
+// monitoring systems such as Prometheus.
+type Metrics struct {
+	Hits      int64
+	Misses    int64
+	Evictions int64
+	Size      int64
+}
+
+// Metrics returns the current hit/miss/eviction counters and cache size.
+func (c *Cache[K, V]) Metrics() Metrics {
+	return Metrics{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+		Size:      c.size.Load(),
+	}
+}
+
+// touch records an access to key for recency/frequency purposes without
+// inserting it; item is already tracked by track at this point. It is used
+// on cache hits.
+func (c *Cache[K, V]) touch(key K, item *cacheItem[V]) {
+	if c.maxEntries <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch c.policy {
+	case LRU:
+		if item.node != nil {
+			c.order.MoveToFront(item.node)
+		}
+	case LFU:
+		if item.freqNode != nil {
+			c.bumpFreqLocked(key, item)
+		}
+	case FIFO:
+		// insertion order only; accesses don't affect eviction order
+	}
+}
+
+// track registers key/item with the eviction policy if it isn't tracked yet
+// (first time it's populated) or otherwise bumps its recency (LRU) or
+// frequency (LFU), leaving FIFO order unchanged, then evicts entries over
+// maxEntries.
+func (c *Cache[K, V]) track(key K, item *cacheItem[V]) {
+	if c.maxEntries <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch c.policy {
+	case LFU:
+		if item.freqNode == nil {
+			item.freq = 1
+			item.freqNode = c.freqBucket(1).PushBack(key)
+			c.minFreq = 1
+		} else {
+			c.bumpFreqLocked(key, item)
+		}
+	default: // LRU, FIFO
+		if item.node == nil {
+			item.node = c.order.PushFront(key)
+		} else if c.policy == LRU {
+			c.order.MoveToFront(item.node)
+		}
+	}
+
+	c.evictIfNeededLocked()
+	c.size.Store(int64(len(c.items)))
+}
+
+// untrackLocked removes key's eviction bookkeeping, e.g. when cleanup deletes
+// an expired item. c.mu must be held by the caller.
+func (c *Cache[K, V]) untrackLocked(key K, item *cacheItem[V]) {
+	if c.maxEntries <= 0 {
+		return
+	}
+
+	if item.node != nil {
+		c.order.Remove(item.node)
+		item.node = nil
+	}
+
+	if item.freqNode != nil {
+		if bucket, ok := c.freqBuckets[item.freq]; ok {
+			bucket.Remove(item.freqNode)
+			if bucket.Len() == 0 {
+				delete(c.freqBuckets, item.freq)
+			}
+		}
+		item.freqNode = nil
+	}
+}
+
+// evictIfNeededLocked removes entries according to policy until the cache is
+// back within maxEntries. c.mu must be held by the caller.
+func (c *Cache[K, V]) evictIfNeededLocked() {
+	for len(c.items) > c.maxEntries {
+		var victim K
+
+		switch c.policy {
+		case LFU:
+			bucket := c.freqBuckets[c.minFreq]
+			if bucket == nil || bucket.Len() == 0 {
+				return
+			}
+			elem := bucket.Front()
+			victim = elem.Value.(K)
+			bucket.Remove(elem)
+			if bucket.Len() == 0 {
+				delete(c.freqBuckets, c.minFreq)
+			}
+		default: // LRU, FIFO
+			elem := c.order.Back()
+			if elem == nil {
+				return
+			}
+			victim = elem.Value.(K)
+			c.order.Remove(elem)
+		}
+
+		victimItem := c.items[victim]
+		delete(c.items, victim)
+		c.evictions.Add(1)
+		if victimItem != nil {
+			c.fire(OnEviction, victim, victimItem.val, ReasonCapacityEviction)
+		}
+	}
+}
+
+// freqBucket returns the LFU bucket for freq, creating it if necessary.
+func (c *Cache[K, V]) freqBucket(freq int) *list.List {
+	bucket, ok := c.freqBuckets[freq]
+	if !ok {
+		bucket = list.New()
+		c.freqBuckets[freq] = bucket
+	}
+	return bucket
+}
+
+// bumpFreqLocked moves key from its current frequency bucket to the next one
+// up. c.mu must be held by the caller.
+func (c *Cache[K, V]) bumpFreqLocked(key K, item *cacheItem[V]) {
+	oldFreq := item.freq
+	if oldBucket, ok := c.freqBuckets[oldFreq]; ok {
+		oldBucket.Remove(item.freqNode)
+		if oldBucket.Len() == 0 {
+			delete(c.freqBuckets, oldFreq)
+			if c.minFreq == oldFreq {
+				c.minFreq++
+			}
+		}
+	}
+
+	item.freq++
+	item.freqNode = c.freqBucket(item.freq).PushBack(key)
+}
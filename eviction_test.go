@@ -0,0 +1,93 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCacheMaxEntriesLRU(t *testing.T) {
+	cache := New(time.Minute, func(key string) (int, error) {
+		return len(key), nil
+	}, WithMaxEntries[string, int](2, LRU))
+
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+
+	// touch "a" so "b" becomes the least recently used
+	_, _ = cache.Get("a")
+
+	cache.Set("c", 3)
+
+	requireEqual(t, 2, len(cache.items))
+	if _, ok := cache.items["b"]; ok {
+		t.Fatalf("expected \"b\" to be evicted")
+	}
+
+	metrics := cache.Metrics()
+	requireEqual(t, int64(1), metrics.Evictions)
+	requireEqual(t, int64(2), metrics.Size)
+}
+
+func TestCacheMaxEntriesFIFO(t *testing.T) {
+	cache := New(time.Minute, func(key string) (int, error) {
+		return len(key), nil
+	}, WithMaxEntries[string, int](2, FIFO))
+
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+
+	// unlike LRU, touching "a" must not save it from FIFO eviction
+	_, _ = cache.Get("a")
+
+	cache.Set("c", 3)
+
+	requireEqual(t, 2, len(cache.items))
+	if _, ok := cache.items["a"]; ok {
+		t.Fatalf("expected \"a\" to be evicted")
+	}
+}
+
+func TestCacheMaxEntriesLFU(t *testing.T) {
+	cache := New(time.Minute, func(key string) (int, error) {
+		return len(key), nil
+	}, WithMaxEntries[string, int](2, LFU))
+
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+
+	// "a" and "b" are both at frequency 1; inserting "c" must evict the
+	// oldest of them ("a"), not the key that was just inserted.
+	cache.Set("c", 3)
+
+	requireEqual(t, 2, len(cache.items))
+	if _, ok := cache.items["a"]; ok {
+		t.Fatalf("expected \"a\" to be evicted")
+	}
+	if _, ok := cache.items["c"]; !ok {
+		t.Fatalf("expected \"c\" to still be present")
+	}
+
+	// bump "b" so it moves to frequency 2, making "c" the sole frequency-1
+	// entry and thus the next victim.
+	_, _ = cache.Get("b")
+	cache.Set("d", 4)
+
+	requireEqual(t, 2, len(cache.items))
+	if _, ok := cache.items["c"]; ok {
+		t.Fatalf("expected \"c\" to be evicted")
+	}
+}
+
+func TestCacheMetrics(t *testing.T) {
+	cache := New(time.Minute, func(key string) (int, error) {
+		return len(key), nil
+	})
+
+	_, _ = cache.Get("a")
+	_, _ = cache.Get("a")
+
+	metrics := cache.Metrics()
+	requireEqual(t, int64(1), metrics.Misses)
+	requireEqual(t, int64(1), metrics.Hits)
+	requireEqual(t, int64(1), metrics.Size)
+}
@@ -0,0 +1,123 @@
+package cache
+
+// EventKind identifies which cache lifecycle event a subscriber is
+// interested in. See Subscribe.
+type EventKind int
+
+const (
+	// OnInsertion fires when a key is given a fresh value, whether via Set or
+	// a successful query.
+	OnInsertion EventKind = iota
+	// OnHit fires when Get is satisfied from the cache without calling query.
+	OnHit
+	// OnMiss fires when Get's query call fails; Reason is ReasonQueryError.
+	OnMiss
+	// OnEviction fires when a key is removed from the cache, whether by TTL
+	// expiration, capacity eviction, or manual deletion.
+	OnEviction
+)
+
+// Reason distinguishes why an OnEviction or OnMiss event occurred.
+type Reason int
+
+const (
+	// ReasonNone is the zero value, used for events where a removal reason
+	// doesn't apply (OnInsertion, OnHit).
+	ReasonNone Reason = iota
+	// ReasonExpired means the item's TTL elapsed and it was purged by cleanup.
+	ReasonExpired
+	// ReasonManualDelete means the item was removed by an explicit Delete or
+	// Purge call.
+	ReasonManualDelete
+	// ReasonCapacityEviction means the item was evicted to stay within
+	// WithMaxEntries.
+	ReasonCapacityEviction
+	// ReasonQueryError means query returned an error for this key.
+	ReasonQueryError
+)
+
+const _subscriberBufferSize = 64
+
+// event is what's sent down a subscriber's channel; fields are exported only
+// within the package so Subscribe's callback can stay a plain function.
+type event[K comparable, V any] struct {
+	key    K
+	value  V
+	reason Reason
+}
+
+// subscriber runs a subscriber's callback in its own goroutine, reading from
+// a buffered channel so a slow callback can't block cache operations.
+type subscriber[K comparable, V any] struct {
+	ch chan event[K, V]
+	fn func(key K, value V, reason Reason)
+}
+
+func (s *subscriber[K, V]) run() {
+	for e := range s.ch {
+		s.fn(e.key, e.value, e.reason)
+	}
+}
+
+// Subscribe registers fn to be called whenever an event of kind occurs. fn
+// runs in a dedicated goroutine fed by a buffered channel, so a slow or
+// blocking callback never delays Get, Set, or cleanup; if the subscriber
+// falls too far behind, excess events are dropped rather than queued
+// unbounded. The returned func unsubscribes and stops that goroutine.
+func (c *Cache[K, V]) Subscribe(kind EventKind, fn func(key K, value V, reason Reason)) func() {
+	sub := &subscriber[K, V]{
+		ch: make(chan event[K, V], _subscriberBufferSize),
+		fn: fn,
+	}
+	go sub.run()
+
+	c.subsMu.Lock()
+	if c.subs == nil {
+		c.subs = make(map[EventKind][]*subscriber[K, V])
+	}
+	c.subs[kind] = append(c.subs[kind], sub)
+	c.subsMu.Unlock()
+
+	var unsubscribed bool
+	return func() {
+		c.subsMu.Lock()
+		defer c.subsMu.Unlock()
+
+		if unsubscribed {
+			return
+		}
+		unsubscribed = true
+
+		subs := c.subs[kind]
+		for i, s := range subs {
+			if s == sub {
+				c.subs[kind] = append(subs[:i], subs[i+1:]...)
+				close(sub.ch)
+				break
+			}
+		}
+	}
+}
+
+// fire notifies every subscriber of kind without blocking the caller. It
+// holds subsMu for the whole (non-blocking) send loop so that it can never
+// overlap with an unsubscribe closing a channel out from under it — the two
+// are fully serialized by subsMu instead of racing.
+func (c *Cache[K, V]) fire(kind EventKind, key K, value V, reason Reason) {
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+
+	subs := c.subs[kind]
+	if len(subs) == 0 {
+		return
+	}
+
+	e := event[K, V]{key: key, value: value, reason: reason}
+	for _, s := range subs {
+		select {
+		case s.ch <- e:
+		default:
+			// subscriber is falling behind; drop rather than block the cache.
+		}
+	}
+}
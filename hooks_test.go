@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheSubscribeInsertionAndHit(t *testing.T) {
+	cache := New(time.Minute, func(key string) (int, error) {
+		return len(key), nil
+	})
+
+	var insertions, hits atomic.Int64
+	unsubInsert := cache.Subscribe(OnInsertion, func(key string, value int, reason Reason) {
+		insertions.Add(1)
+	})
+	defer unsubInsert()
+
+	unsubHit := cache.Subscribe(OnHit, func(key string, value int, reason Reason) {
+		hits.Add(1)
+	})
+	defer unsubHit()
+
+	_, _ = cache.Get("test")
+	_, _ = cache.Get("test")
+
+	deadline := time.After(time.Second)
+	for insertions.Load() != 1 || hits.Load() != 1 {
+		select {
+		case <-deadline:
+			t.Fatalf("expected 1 insertion and 1 hit, got insertions=%d hits=%d", insertions.Load(), hits.Load())
+		default:
+		}
+	}
+}
+
+func TestCacheSubscribeEvictionOnExpiry(t *testing.T) {
+	cache := New(time.Second, func(key string) (int, error) {
+		return len(key), nil
+	}, WithCleanupInterval[string, int](time.Second))
+
+	var reason atomic.Int64
+	unsub := cache.Subscribe(OnEviction, func(key string, value int, r Reason) {
+		reason.Store(int64(r))
+	})
+	defer unsub()
+
+	cache.Set("test", 10, time.Second)
+
+	<-time.After(3 * time.Second)
+
+	requireEqual(t, int64(ReasonExpired), reason.Load())
+}
+
+// TestCacheSubscribeUnsubscribeRace repeatedly subscribes and immediately
+// unsubscribes concurrently with Set traffic that fires OnInsertion, which
+// used to race fire's send against unsubscribe's close of the
+// same channel (detectable with -race, and otherwise liable to panic with
+// "send on closed channel").
+func TestCacheSubscribeUnsubscribeRace(t *testing.T) {
+	cache := New(time.Minute, func(key string) (int, error) {
+		return len(key), nil
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			cache.Set("test", i)
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			unsub := cache.Subscribe(OnInsertion, func(key string, value int, reason Reason) {})
+			unsub()
+		}
+	}()
+
+	wg.Wait()
+}